@@ -0,0 +1,256 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package spellcheck defines an Analyzer that reports misspelled words in
+// Go identifiers, comments and string literals, judged against a real
+// Hunspell dictionary rather than the static word list used by tools
+// such as client9/misspell.
+package spellcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/kortschak/hunspell"
+)
+
+// Analyzer reports misspelled words in Go identifiers, comments and
+// string literals.
+var Analyzer = &analysis.Analyzer{
+	Name:     "spellcheck",
+	Doc:      "check spelling of identifiers, comments and string literals",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var (
+	dictPath  string
+	dictLang  string
+	extraDict stringList
+	ignoreCSV string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&dictPath, "dict", "/usr/share/hunspell", "path to the hunspell dictionary directory")
+	Analyzer.Flags.StringVar(&dictLang, "lang", "en_US", "dictionary language key")
+	Analyzer.Flags.Var(&extraDict, "extra-dict", "additional .dic file to load via Spell.AddDict (may be repeated)")
+	Analyzer.Flags.StringVar(&ignoreCSV, "ignore", "", "comma-separated words to ignore, added to the run-time dictionary")
+}
+
+// stringList is a flag.Value that accumulates repeated flag occurrences.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+var (
+	spellOnce sync.Once
+	spell     *hunspell.SafeSpell
+	spellErr  error
+)
+
+// getSpell lazily constructs the SafeSpell used by the analyzer from its
+// flags. It is built once per process since analysis.Analyzer flags are
+// fixed for the lifetime of a run. go/analysis drivers such as go vet
+// and golangci-lint run Analyzer.Run concurrently across packages, so
+// the shared checker is wrapped in SafeSpell rather than handed out as
+// a bare *hunspell.Spell.
+func getSpell() (*hunspell.SafeSpell, error) {
+	spellOnce.Do(func() {
+		s, err := hunspell.NewSpell(dictPath, dictLang)
+		if err != nil {
+			spellErr = err
+			return
+		}
+		for _, d := range extraDict {
+			if err := s.AddDict(d); err != nil {
+				spellErr = err
+				return
+			}
+		}
+		for _, w := range strings.Split(ignoreCSV, ",") {
+			w = strings.TrimSpace(w)
+			if w != "" {
+				s.Add(w)
+			}
+		}
+		spell = hunspell.NewSafeSpell(s)
+	})
+	return spell, spellErr
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	s, err := getSpell()
+	if err != nil {
+		return nil, err
+	}
+
+	// check reports each misspelled word split out of text with its own
+	// position, derived from base plus the word's byte offset within
+	// text, so that a SuggestedFix replaces only the misspelled word
+	// rather than the whole enclosing identifier, comment or string.
+	check := func(base token.Pos, text string) {
+		for _, w := range splitWordOffsets(text) {
+			if len(w.text) < 3 || isKeyword(w.text) {
+				continue
+			}
+			if s.IsCorrect(w.text) {
+				continue
+			}
+			pos := base + token.Pos(w.offset)
+			reportMisspelling(pass, s, pos, pos+token.Pos(len(w.text)), w.text)
+		}
+	}
+
+	noCheck := nonCheckableLits(pass.Files)
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.Ident)(nil),
+		(*ast.BasicLit)(nil),
+	}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if shouldSkipIdent(pass, n) {
+				return
+			}
+			check(n.Pos(), n.Name)
+		case *ast.BasicLit:
+			if n.Kind != token.STRING || noCheck[n] {
+				return
+			}
+			text, err := strconv.Unquote(n.Value)
+			if err != nil {
+				return
+			}
+			check(n.Pos()+1, text)
+		}
+	})
+
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				prefix := "//"
+				text := strings.TrimPrefix(c.Text, prefix)
+				if text == c.Text {
+					prefix = "/*"
+					text = strings.TrimPrefix(c.Text, prefix)
+				}
+				check(c.Pos()+token.Pos(len(prefix)), text)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// shouldSkipIdent reports whether ident should not be spell-checked
+// because it is the blank identifier, a package name, or an identifier
+// defined by an imported package.
+func shouldSkipIdent(pass *analysis.Pass, ident *ast.Ident) bool {
+	if ident.Name == "_" {
+		return true
+	}
+	for _, f := range pass.Files {
+		if f.Name == ident {
+			return true
+		}
+	}
+	obj := pass.TypesInfo.ObjectOf(ident)
+	if obj == nil {
+		return false
+	}
+	if _, ok := obj.(*types.PkgName); ok {
+		return true
+	}
+	if pkg := obj.Pkg(); pkg != nil && pkg != pass.Pkg {
+		return true
+	}
+	return false
+}
+
+// nonCheckableLits returns the set of *ast.BasicLit string literals in
+// files that must not be spell-checked because splitting them into
+// words would be meaningless or, for an import path, unsafe to fix:
+// import.ImportSpec.Path and struct field tags.
+func nonCheckableLits(files []*ast.File) map[*ast.BasicLit]bool {
+	skip := make(map[*ast.BasicLit]bool)
+	for _, f := range files {
+		for _, imp := range f.Imports {
+			skip[imp.Path] = true
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			st, ok := n.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range st.Fields.List {
+				if field.Tag != nil {
+					skip[field.Tag] = true
+				}
+			}
+			return true
+		})
+	}
+	return skip
+}
+
+// reportMisspelling reports a diagnostic for word, offering the
+// best-matching suggestion as a SuggestedFix when Spell.Suggest finds
+// one that preserves word's case.
+func reportMisspelling(pass *analysis.Pass, s *hunspell.SafeSpell, pos, end token.Pos, word string) {
+	d := analysis.Diagnostic{
+		Pos:     pos,
+		End:     end,
+		Message: "possible misspelling of \"" + word + "\"",
+	}
+	if suggestion := bestSuggestion(s, word); suggestion != "" {
+		d.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "change to \"" + suggestion + "\"",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     pos,
+				End:     end,
+				NewText: []byte(suggestion),
+			}},
+		}}
+	}
+	pass.Report(d)
+}
+
+// bestSuggestion returns the first Spell.Suggest candidate for word that
+// preserves its case pattern, or "" if there is none.
+func bestSuggestion(s *hunspell.SafeSpell, word string) string {
+	for _, cand := range s.Suggest(word) {
+		if sameCase(word, cand) {
+			return cand
+		}
+	}
+	return ""
+}
+
+// sameCase reports whether cand follows the same broad case pattern as
+// word: all upper case, leading upper case, or all lower case.
+func sameCase(word, cand string) bool {
+	switch {
+	case word == strings.ToUpper(word):
+		return cand == strings.ToUpper(cand)
+	case len(word) != 0 && 'A' <= word[0] && word[0] <= 'Z':
+		return len(cand) != 0 && 'A' <= cand[0] && cand[0] <= 'Z'
+	default:
+		return cand == strings.ToLower(cand)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spellcheck
+
+import (
+	"os/exec"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer runs Analyzer against testdata/src/a, which has a
+// misspelling inside a multi-word snake_case identifier and another
+// inside a multi-word comment, and checks that the SuggestedFix for
+// each replaces only the misspelled word, not its enclosing span.
+// It also runs testdata/src/b, which has a multi-segment import path
+// and a struct tag that must not be split into words and reported,
+// a package clause name that is not an English word and must not be
+// reported, and the same misspelling repeated across two files of
+// the package, both of which must be reported.
+func TestAnalyzer(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Skipf("hunspell not available for testing: %v", err)
+	}
+
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a", "b")
+}
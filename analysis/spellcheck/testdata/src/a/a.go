@@ -0,0 +1,6 @@
+package a
+
+func check_langauge() {} // want `possible misspelling of "la(n)gauge"`
+
+// This sentence contains a seperate kind of mistake. // want `possible misspelling of "se(per)ate"`
+var x = 1
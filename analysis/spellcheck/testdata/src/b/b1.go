@@ -0,0 +1,13 @@
+package xyzzy
+
+import (
+	"net/http/httptest"
+)
+
+type T struct {
+	F string `json:"f,omitempty"`
+}
+
+func check_langauge() {} // want `possible misspelling of "langauge"`
+
+var _ = httptest.NewServer
@@ -0,0 +1,3 @@
+package xyzzy
+
+func another_langauge() {} // want `possible misspelling of "la(n)gauge"`
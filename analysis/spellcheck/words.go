@@ -0,0 +1,99 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spellcheck
+
+import (
+	"go/token"
+	"unicode"
+)
+
+// word is a word split out of a larger identifier, comment or string,
+// together with its byte offset within that text, so that callers can
+// report diagnostics and build SuggestedFixes scoped to the word itself
+// rather than its enclosing span.
+type word struct {
+	text   string
+	offset int
+}
+
+// splitWords splits text into its constituent words, breaking on
+// snake_case underscores, camelCase and PascalCase boundaries, and any
+// character that is not a letter, digit or apostrophe (so that prose in
+// comments and string literals splits on whitespace and punctuation,
+// while contractions such as "don't" stay a single word). Runs of upper
+// case letters are treated as a single word unless followed by a lower
+// case letter, so that initialisms such as "URL" in "URLPath" split as
+// "URL", "Path".
+func splitWords(ident string) []string {
+	ws := splitWordOffsets(ident)
+	if len(ws) == 0 {
+		return nil
+	}
+	words := make([]string, len(ws))
+	for i, w := range ws {
+		words[i] = w.text
+	}
+	return words
+}
+
+// splitWordOffsets behaves like splitWords but also records each word's
+// byte offset within ident.
+func splitWordOffsets(ident string) []word {
+	var words []word
+	var cur []rune
+	var offset int
+	runes := []rune(ident)
+	byteOffsets := make([]int, len(runes)+1)
+	for i, b := 0, 0; i < len(runes); i++ {
+		byteOffsets[i] = b
+		b += len(string(runes[i]))
+		byteOffsets[i+1] = b
+	}
+	flush := func(end int) {
+		if len(cur) != 0 {
+			words = append(words, word{text: string(cur), offset: offset})
+			cur = cur[:0]
+		}
+		offset = end
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			flush(byteOffsets[i+1])
+		case unicode.IsUpper(r) && i != 0 &&
+			(unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush(byteOffsets[i])
+			cur = append(cur, r)
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'':
+			flush(byteOffsets[i+1])
+		default:
+			if len(cur) == 0 {
+				offset = byteOffsets[i]
+			}
+			cur = append(cur, r)
+		}
+	}
+	flush(0)
+	return words
+}
+
+// isKeyword returns whether word is a Go keyword or predeclared
+// identifier, neither of which should be spell-checked.
+func isKeyword(word string) bool {
+	if token.Lookup(word).IsKeyword() {
+		return true
+	}
+	switch word {
+	case "bool", "byte", "complex64", "complex128", "error", "float32",
+		"float64", "int", "int8", "int16", "int32", "int64", "rune",
+		"string", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"true", "false", "iota", "nil",
+		"append", "cap", "close", "complex", "copy", "delete", "imag",
+		"len", "make", "new", "panic", "print", "println", "real", "recover":
+		return true
+	}
+	return false
+}
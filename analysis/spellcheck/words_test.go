@@ -0,0 +1,65 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spellcheck
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		ident string
+		want  []string
+	}{
+		{"word", []string{"word"}},
+		{"camelCase", []string{"camel", "Case"}},
+		{"PascalCase", []string{"Pascal", "Case"}},
+		{"snake_case", []string{"snake", "case"}},
+		{"SCREAMING_SNAKE", []string{"SCREAMING", "SNAKE"}},
+		{"URLPath", []string{"URL", "Path"}},
+		{"parseURL", []string{"parse", "URL"}},
+		{"_", nil},
+		{"a sentence with words", []string{"a", "sentence", "with", "words"}},
+		{`a "quoted" word`, []string{"a", "quoted", "word"}},
+		{"don't split", []string{"don't", "split"}},
+	}
+	for _, test := range tests {
+		got := splitWords(test.ident)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected result for splitWords(%q): got:%#v want:%#v", test.ident, got, test.want)
+		}
+	}
+}
+
+func TestSplitWordOffsets(t *testing.T) {
+	tests := []struct {
+		ident string
+		want  []word
+	}{
+		{"word", []word{{"word", 0}}},
+		{"myBadWrod", []word{{"my", 0}, {"Bad", 2}, {"Wrod", 5}}},
+		{"a sentence", []word{{"a", 0}, {"sentence", 2}}},
+	}
+	for _, test := range tests {
+		got := splitWordOffsets(test.ident)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected result for splitWordOffsets(%q): got:%#v want:%#v", test.ident, got, test.want)
+		}
+	}
+}
+
+func TestIsKeyword(t *testing.T) {
+	for _, w := range []string{"func", "range", "string", "nil", "append"} {
+		if !isKeyword(w) {
+			t.Errorf("expected %q to be treated as a keyword", w)
+		}
+	}
+	for _, w := range []string{"language", "necessary"} {
+		if isKeyword(w) {
+			t.Errorf("did not expect %q to be treated as a keyword", w)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Misspelling describes a single word that was not found in the
+// dictionary.
+type Misspelling struct {
+	// Word is the misspelled word as it appeared in the input.
+	Word string
+	// Offset is the byte offset of Word within the checked input.
+	Offset int
+	// Line and Col are the 1-based line and column, in runes, of the
+	// start of Word within the checked input.
+	Line, Col int
+
+	s           *Spell
+	suggestions []string
+	haveSuggest bool
+}
+
+// Suggestions returns candidate corrections for Word. The underlying
+// Spell.Suggest call is made on first use and the result cached, so
+// that callers who only need Word and its position, for example a
+// misspelling count or a CheckWriter filter, don't pay for it.
+func (m *Misspelling) Suggestions() []string {
+	if !m.haveSuggest {
+		m.suggestions = m.s.Suggest(m.Word)
+		m.haveSuggest = true
+	}
+	return m.suggestions
+}
+
+// Check reads text from r and returns the words that are not found in
+// the dictionary, along with their positions and suggested corrections.
+func (s *Spell) Check(r io.Reader) ([]Misspelling, error) {
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanRunes)
+
+	var (
+		misspellings []Misspelling
+		word         []byte
+		wordOffset   int
+		offset       int
+		line, col    = 1, 1
+	)
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		w := string(word)
+		if !s.IsCorrect(w) {
+			misspellings = append(misspellings, Misspelling{
+				Word:   w,
+				Offset: wordOffset,
+				Line:   line,
+				Col:    col - utf8.RuneCountInString(w),
+				s:      s,
+			})
+		}
+		word = word[:0]
+	}
+	for sc.Scan() {
+		b := sc.Bytes()
+		r, _ := utf8.DecodeRune(b)
+		if isWordRune(r) {
+			if len(word) == 0 {
+				wordOffset = offset
+			}
+			word = append(word, b...)
+		} else {
+			flush()
+		}
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+		offset += len(b)
+	}
+	flush()
+	if err := sc.Err(); err != nil {
+		return misspellings, err
+	}
+	return misspellings, nil
+}
+
+// CheckString returns the words in s that are not found in the
+// dictionary, along with their positions and suggested corrections.
+func (s *Spell) CheckString(text string) []Misspelling {
+	// Check never returns an error for a strings.Reader.
+	m, _ := s.Check(strings.NewReader(text))
+	return m
+}
+
+// isWordRune reports whether r should be treated as part of a word. It
+// accepts letters, digits and the apostrophe, so that contractions such
+// as "don't" are treated as a single word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+}
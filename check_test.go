@@ -0,0 +1,71 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+)
+
+func TestCheckString(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Fatalf("hunspell not available for testing: %v", err)
+	}
+
+	s, err := NewSpell(path, lang)
+	if err != nil {
+		t.Fatalf("failed to open dictionary: %v", err)
+	}
+
+	const text = "This is seperate from langauge, really."
+	got := s.CheckString(text)
+	want := []string{"seperate", "langauge"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of misspellings: got:%d want:%d", len(got), len(want))
+	}
+	for i := range got {
+		m := &got[i]
+		if m.Word != want[i] {
+			t.Errorf("unexpected misspelling at index %d: got:%q want:%q", i, m.Word, want[i])
+		}
+		if len(m.Suggestions()) == 0 {
+			t.Errorf("expected suggestions for %q", m.Word)
+		}
+	}
+}
+
+func TestCheckWriter(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Fatalf("hunspell not available for testing: %v", err)
+	}
+
+	s, err := NewSpell(path, lang)
+	if err != nil {
+		t.Fatalf("failed to open dictionary: %v", err)
+	}
+
+	var out bytes.Buffer
+	cw := s.CheckWriter(&out)
+	const text = "seperate words"
+	n, err := cw.Write([]byte(text))
+	if err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+	if n != len(text) {
+		t.Errorf("unexpected short write: got:%d want:%d", n, len(text))
+	}
+	if out.String() != text {
+		t.Errorf("write was not passed through unmodified: got:%q want:%q", out.String(), text)
+	}
+
+	got, err := cw.Check()
+	if err != nil {
+		t.Fatalf("unexpected error checking: %v", err)
+	}
+	if len(got) != 1 || got[0].Word != "seperate" {
+		t.Errorf("unexpected misspellings: got:%#v", got)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"bytes"
+	"io"
+)
+
+// CheckWriter wraps an io.Writer, passing written bytes through
+// unmodified while buffering them so that they can be spell checked,
+// letting callers use a Spell as a transparent filter in command-line
+// tools that both display and check text.
+type CheckWriter struct {
+	s   *Spell
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+// CheckWriter returns a CheckWriter that writes through to w.
+func (s *Spell) CheckWriter(w io.Writer) *CheckWriter {
+	return &CheckWriter{s: s, w: w}
+}
+
+// Write writes p to the wrapped writer, buffering a copy for a later
+// call to Check.
+func (c *CheckWriter) Write(p []byte) (int, error) {
+	c.buf.Write(p)
+	return c.w.Write(p)
+}
+
+// Check returns the misspellings found in all of the text written to c
+// so far.
+func (c *CheckWriter) Check() ([]Misspelling, error) {
+	return c.s.Check(bytes.NewReader(c.buf.Bytes()))
+}
@@ -0,0 +1,146 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// textToCheck returns the text that should be spell checked for a
+// document with the given languageId. The returned text is always the
+// same length, in bytes, as text, with everything that should not be
+// checked replaced by spaces, so that byte offsets into the result
+// remain valid offsets into the original document.
+func textToCheck(languageID, text string) string {
+	switch languageID {
+	case "markdown":
+		return maskMarkdownCode(text)
+	case "go":
+		return maskGoCode(text)
+	default:
+		return text
+	}
+}
+
+// maskBytes returns a copy of text with every byte that is not part of a
+// line ending replaced by a space.
+func maskBytes(text string) []byte {
+	b := make([]byte, len(text))
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c == '\n' || c == '\r' {
+			b[i] = c
+		} else {
+			b[i] = ' '
+		}
+	}
+	return b
+}
+
+// keepRange copies text[start:end] from text into mask, unmasking that
+// byte range.
+func keepRange(mask []byte, text string, start, end int) {
+	copy(mask[start:end], text[start:end])
+}
+
+// maskMarkdownCode masks the contents of fenced code blocks (delimited
+// by lines starting with ``` or ~~~) so that code samples are not
+// spell checked.
+func maskMarkdownCode(text string) string {
+	mask := maskBytes(text)
+	var (
+		inFence bool
+		fence   string
+		offset  int
+	)
+	for _, line := range splitLinesKeepEnds(text) {
+		trimmed := strings.TrimSpace(line)
+		isFenceMarker := strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+		switch {
+		case inFence && isFenceMarker && strings.HasPrefix(trimmed, fence):
+			inFence = false
+		case !inFence && isFenceMarker:
+			inFence = true
+			fence = trimmed[:3]
+		case !inFence:
+			keepRange(mask, text, offset, offset+len(line))
+		}
+		offset += len(line)
+	}
+	return string(mask)
+}
+
+// maskGoCode masks everything in text except the contents of comments
+// and string literals, so that only prose and string contents are
+// spell checked.
+func maskGoCode(text string) string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", text, parser.ParseComments)
+	if err != nil {
+		// text does not parse, most likely because it is being edited;
+		// mask everything out so we don't report diagnostics against
+		// positions in code we can't account for.
+		return string(maskBytes(text))
+	}
+
+	mask := maskBytes(text)
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			keepRange(mask, text, fset.Position(c.Pos()).Offset, fset.Position(c.End()).Offset)
+		}
+	}
+	noKeep := nonCheckableLits(f)
+	ast.Inspect(f, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if ok && lit.Kind == token.STRING && !noKeep[lit] {
+			keepRange(mask, text, fset.Position(lit.Pos()).Offset, fset.Position(lit.End()).Offset)
+		}
+		return true
+	})
+	return string(mask)
+}
+
+// nonCheckableLits returns the set of *ast.BasicLit string literals in
+// f that must not be unmasked for spell checking because they are not
+// prose: import.ImportSpec.Path and struct field tags.
+func nonCheckableLits(f *ast.File) map[*ast.BasicLit]bool {
+	skip := make(map[*ast.BasicLit]bool)
+	for _, imp := range f.Imports {
+		skip[imp.Path] = true
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		st, ok := n.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, field := range st.Fields.List {
+			if field.Tag != nil {
+				skip[field.Tag] = true
+			}
+		}
+		return true
+	})
+	return skip
+}
+
+// splitLinesKeepEnds splits text into lines, each including its
+// terminating "\n", if any.
+func splitLinesKeepEnds(text string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			lines = append(lines, text[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(text) {
+		lines = append(lines, text[start:])
+	}
+	return lines
+}
@@ -0,0 +1,74 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaskMarkdownCode(t *testing.T) {
+	const src = "prose with a mistake\n```go\ncode mistake\n```\nmore prose\n"
+	got := maskMarkdownCode(src)
+	if len(got) != len(src) {
+		t.Fatalf("mask changed length: got:%d want:%d", len(got), len(src))
+	}
+	if !strings.Contains(got, "prose with a mistake") {
+		t.Errorf("prose was masked: %q", got)
+	}
+	if !strings.Contains(got, "more prose") {
+		t.Errorf("trailing prose was masked: %q", got)
+	}
+	if strings.Contains(got, "code mistake") {
+		t.Errorf("fenced code was not masked: %q", got)
+	}
+}
+
+func TestMaskGoCode(t *testing.T) {
+	const src = `package p
+
+// a comment with a typo
+func f() {
+	x := "a string with a typo"
+	_ = x
+}
+`
+	got := maskGoCode(src)
+	if len(got) != len(src) {
+		t.Fatalf("mask changed length: got:%d want:%d", len(got), len(src))
+	}
+	if !strings.Contains(got, "a comment with a typo") {
+		t.Errorf("comment was masked: %q", got)
+	}
+	if !strings.Contains(got, "a string with a typo") {
+		t.Errorf("string literal was masked: %q", got)
+	}
+	if strings.Contains(got, "package") || strings.Contains(got, "func") {
+		t.Errorf("code was not masked: %q", got)
+	}
+}
+
+func TestMaskGoCodeImportsAndTags(t *testing.T) {
+	const src = `package p
+
+import "golang.org/x/tools/go/analysis"
+
+type T struct {
+	F string ` + "`json:\"f,omitempty\"`" + `
+}
+
+var _ = analysis.Analyzer{}
+`
+	got := maskGoCode(src)
+	if len(got) != len(src) {
+		t.Fatalf("mask changed length: got:%d want:%d", len(got), len(src))
+	}
+	if strings.Contains(got, "golang.org/x/tools/go/analysis") {
+		t.Errorf("import path was not masked: %q", got)
+	}
+	if strings.Contains(got, "json:") || strings.Contains(got, "omitempty") {
+		t.Errorf("struct tag was not masked: %q", got)
+	}
+}
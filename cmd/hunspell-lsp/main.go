@@ -0,0 +1,49 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command hunspell-lsp is a language server that reports misspelled
+// words in plain text, Markdown prose and Go comments and string
+// literals as diagnostics, backed by the hunspell package.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/kortschak/hunspell"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("hunspell-lsp: ")
+
+	var (
+		dictPath  = flag.String("dict", "/usr/share/hunspell", "path to the hunspell dictionary directory")
+		dictLang  = flag.String("lang", "en_US", "dictionary language key")
+		extraDict = flag.String("extra-dict", "", "comma-separated list of additional .dic files to load")
+		userDict  = flag.String("user-dict", "", "path to a user dictionary file persisted by the \"Add to dictionary\" code action")
+	)
+	flag.Parse()
+
+	s, err := hunspell.NewSpell(*dictPath, *dictLang)
+	if err != nil {
+		log.Fatalf("failed to open dictionary: %v", err)
+	}
+	for _, d := range strings.Split(*extraDict, ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		if err := s.AddDict(d); err != nil {
+			log.Fatalf("failed to add dictionary %q: %v", d, err)
+		}
+	}
+
+	srv := newServer(s, *userDict)
+	if err := srv.serve(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/kortschak/hunspell"
+)
+
+// server holds the state of a single hunspell-lsp session: the spelling
+// checker shared by all documents, and the text of every open document.
+type server struct {
+	spell *hunspell.Spell
+
+	mu       sync.Mutex
+	docs     map[string]*document
+	userDict string
+}
+
+// document is the last text reported for an open file.
+type document struct {
+	languageID string
+	version    int
+	text       string
+}
+
+// newServer returns a server backed by s. If userDict is not empty, its
+// contents are loaded into s as a run-time dictionary, and words added
+// by the "Add to dictionary" code action are appended to it.
+func newServer(s *hunspell.Spell, userDict string) *server {
+	srv := &server{spell: s, docs: make(map[string]*document), userDict: userDict}
+	if userDict != "" {
+		if data, err := os.ReadFile(userDict); err == nil {
+			for _, w := range strings.Fields(string(data)) {
+				s.Add(w)
+			}
+		}
+	}
+	return srv
+}
+
+// serve reads JSON-RPC requests from r and writes responses and
+// notifications to w until r is exhausted or a message cannot be read
+// from it.
+func (srv *server) serve(r io.Reader, w io.Writer) error {
+	br := bufio.NewReaderSize(r, 1<<16)
+	var wmu sync.Mutex
+	send := func(v interface{}) error {
+		wmu.Lock()
+		defer wmu.Unlock()
+		return writeMessage(w, v)
+	}
+	for {
+		body, err := readMessage(br)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			continue
+		}
+		srv.handle(req, send)
+	}
+}
+
+func (srv *server) handle(req request, send func(interface{}) error) {
+	switch req.Method {
+	case "initialize":
+		send(response{JSONRPC: "2.0", ID: req.ID, Result: initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncOptions{OpenClose: true, Change: textDocumentSyncFull},
+				CodeActionProvider: true,
+				ExecuteCommand:     executeCommandOptions{Commands: []string{addWordCommand}},
+			},
+		}})
+	case "shutdown":
+		send(response{JSONRPC: "2.0", ID: req.ID})
+	case "exit":
+		os.Exit(0)
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return
+		}
+		srv.mu.Lock()
+		srv.docs[p.TextDocument.URI] = &document{
+			languageID: p.TextDocument.LanguageID,
+			version:    p.TextDocument.Version,
+			text:       p.TextDocument.Text,
+		}
+		srv.mu.Unlock()
+		srv.publishDiagnostics(p.TextDocument.URI, send)
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := json.Unmarshal(req.Params, &p); err != nil || len(p.ContentChanges) == 0 {
+			return
+		}
+		srv.mu.Lock()
+		if doc, ok := srv.docs[p.TextDocument.URI]; ok {
+			doc.version = p.TextDocument.Version
+			doc.text = p.ContentChanges[len(p.ContentChanges)-1].Text
+		}
+		srv.mu.Unlock()
+		srv.publishDiagnostics(p.TextDocument.URI, send)
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := json.Unmarshal(req.Params, &p); err == nil {
+			srv.mu.Lock()
+			delete(srv.docs, p.TextDocument.URI)
+			srv.mu.Unlock()
+		}
+	case "textDocument/codeAction":
+		var p codeActionParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return
+		}
+		send(response{JSONRPC: "2.0", ID: req.ID, Result: srv.codeActions(p)})
+	case "workspace/executeCommand":
+		var p executeCommandParams
+		if err := json.Unmarshal(req.Params, &p); err == nil {
+			srv.executeCommand(p, send)
+		}
+		if len(req.ID) != 0 {
+			send(response{JSONRPC: "2.0", ID: req.ID})
+		}
+	default:
+		if len(req.ID) != 0 {
+			send(response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{
+				Code:    -32601,
+				Message: "method not found: " + req.Method,
+			}})
+		}
+	}
+}
+
+// publishDiagnostics spell checks the document named by uri and sends a
+// textDocument/publishDiagnostics notification for it.
+func (srv *server) publishDiagnostics(uri string, send func(interface{}) error) {
+	text, languageID, ok := srv.document(uri)
+	if !ok {
+		return
+	}
+	diags := srv.diagnostics(text, languageID)
+	send(notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  publishDiagnosticsParams{URI: uri, Diagnostics: diags},
+	})
+}
+
+func (srv *server) document(uri string) (text, languageID string, ok bool) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	doc, ok := srv.docs[uri]
+	if !ok {
+		return "", "", false
+	}
+	return doc.text, doc.languageID, true
+}
+
+// diagnostics returns a diagnostic for each misspelling found in the
+// parts of text relevant to languageID.
+func (srv *server) diagnostics(text, languageID string) []diagnostic {
+	miss := srv.spell.CheckString(textToCheck(languageID, text))
+	diags := make([]diagnostic, 0, len(miss))
+	for _, m := range miss {
+		diags = append(diags, diagnostic{
+			Range:    wordRange(text, m),
+			Severity: severityInformation,
+			Source:   "hunspell",
+			Message:  fmt.Sprintf("possible misspelling of %q", m.Word),
+		})
+	}
+	return diags
+}
+
+// codeActions returns the quick fixes available for the misspellings
+// overlapping p.Range: one action per suggestion that replaces the word,
+// plus an action that adds the word to the dictionary.
+func (srv *server) codeActions(p codeActionParams) []codeAction {
+	text, languageID, ok := srv.document(p.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+
+	var actions []codeAction
+	for _, m := range srv.spell.CheckString(textToCheck(languageID, text)) {
+		r := wordRange(text, m)
+		if !rangesOverlap(r, p.Range) {
+			continue
+		}
+		d := diagnostic{
+			Range:    r,
+			Severity: severityInformation,
+			Source:   "hunspell",
+			Message:  fmt.Sprintf("possible misspelling of %q", m.Word),
+		}
+		for _, sugg := range m.Suggestions() {
+			actions = append(actions, codeAction{
+				Title:       fmt.Sprintf("Change to %q", sugg),
+				Kind:        codeActionKindQuickFix,
+				Diagnostics: []diagnostic{d},
+				Edit: &workspaceEdit{Changes: map[string][]textEdit{
+					p.TextDocument.URI: {{Range: r, NewText: sugg}},
+				}},
+			})
+		}
+		actions = append(actions, codeAction{
+			Title:       fmt.Sprintf("Add %q to dictionary", m.Word),
+			Kind:        codeActionKindQuickFix,
+			Diagnostics: []diagnostic{d},
+			Command: &command{
+				Title:     fmt.Sprintf("Add %q to dictionary", m.Word),
+				Command:   addWordCommand,
+				Arguments: []interface{}{m.Word, p.TextDocument.URI},
+			},
+		})
+	}
+	return actions
+}
+
+// executeCommand handles the addWordCommand, adding its word argument to
+// the run-time dictionary and, if a user dictionary path was configured,
+// persisting it there. If a document URI was passed as a second
+// argument, that document's diagnostics are republished.
+func (srv *server) executeCommand(p executeCommandParams, send func(interface{}) error) {
+	if p.Command != addWordCommand || len(p.Arguments) == 0 {
+		return
+	}
+	word, ok := p.Arguments[0].(string)
+	if !ok || word == "" {
+		return
+	}
+	srv.spell.Add(word)
+	if srv.userDict != "" {
+		f, err := os.OpenFile(srv.userDict, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err == nil {
+			fmt.Fprintln(f, word)
+			f.Close()
+		}
+	}
+	if len(p.Arguments) > 1 {
+		if uri, ok := p.Arguments[1].(string); ok {
+			srv.publishDiagnostics(uri, send)
+		}
+	}
+}
+
+// wordRange returns the LSP range of m.Word within text, given that
+// m.Offset is a byte offset into text.
+func wordRange(text string, m hunspell.Misspelling) rng {
+	return rng{
+		Start: offsetToPosition(text, m.Offset),
+		End:   offsetToPosition(text, m.Offset+len(m.Word)),
+	}
+}
+
+// offsetToPosition converts a byte offset into text to an LSP Position,
+// whose character is a count of UTF-16 code units from the start of the
+// line, as required by the protocol.
+func offsetToPosition(text string, offset int) position {
+	line, lineStart := 0, 0
+	for i := 0; i < offset && i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r == '\n' {
+			line++
+			lineStart = i + size
+		}
+		i += size
+	}
+	char := 0
+	for i := lineStart; i < offset; {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r > 0xFFFF {
+			char += 2
+		} else {
+			char++
+		}
+		i += size
+	}
+	return position{Line: line, Character: char}
+}
+
+func rangesOverlap(a, b rng) bool {
+	return !positionLess(a.End, b.Start) && !positionLess(b.End, a.Start)
+}
+
+func positionLess(a, b position) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Character < b.Character
+}
@@ -0,0 +1,187 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kortschak/hunspell"
+)
+
+// Language and dictionary path used for testing, matching the
+// convention used by the hunspell package's own tests.
+const (
+	testLang = "en_US"
+	testPath = "/usr/share/hunspell"
+)
+
+func TestOffsetToPosition(t *testing.T) {
+	// "café 🎉\nb" has a multi-byte rune on the first line (é, 2 UTF-8
+	// bytes, 1 UTF-16 unit) and an astral rune (🎉, 4 UTF-8 bytes, 2
+	// UTF-16 units, a surrogate pair), followed by a second line.
+	const text = "café 🎉\nb"
+
+	cases := []struct {
+		offset int
+		want   position
+	}{
+		{offset: 0, want: position{Line: 0, Character: 0}},
+		{offset: 3, want: position{Line: 0, Character: 3}},             // start of "é"
+		{offset: 5, want: position{Line: 0, Character: 4}},             // start of " " after "café"
+		{offset: 6, want: position{Line: 0, Character: 5}},             // start of "🎉"
+		{offset: 10, want: position{Line: 0, Character: 7}},            // end of "🎉", counted as 2 units
+		{offset: len(text) - 1, want: position{Line: 1, Character: 0}}, // start of "b"
+	}
+	for _, c := range cases {
+		got := offsetToPosition(text, c.offset)
+		if got != c.want {
+			t.Errorf("offsetToPosition(%q, %d) = %+v, want %+v", text, c.offset, got, c.want)
+		}
+	}
+}
+
+func TestRangesOverlap(t *testing.T) {
+	a := rng{Start: position{Line: 0, Character: 2}, End: position{Line: 0, Character: 5}}
+	cases := []struct {
+		b    rng
+		want bool
+	}{
+		{b: rng{Start: position{Line: 0, Character: 0}, End: position{Line: 0, Character: 2}}, want: true},
+		{b: rng{Start: position{Line: 0, Character: 5}, End: position{Line: 0, Character: 8}}, want: true},
+		{b: rng{Start: position{Line: 0, Character: 0}, End: position{Line: 0, Character: 1}}, want: false},
+		{b: rng{Start: position{Line: 0, Character: 6}, End: position{Line: 0, Character: 8}}, want: false},
+		{b: rng{Start: position{Line: 1, Character: 0}, End: position{Line: 1, Character: 1}}, want: false},
+	}
+	for _, c := range cases {
+		if got := rangesOverlap(a, c.b); got != c.want {
+			t.Errorf("rangesOverlap(%+v, %+v) = %t, want %t", a, c.b, got, c.want)
+		}
+	}
+}
+
+// newTestServer returns a server backed by a real *hunspell.Spell built
+// from the system dictionary, skipping the test if hunspell is not
+// available.
+func newTestServer(t *testing.T, userDict string) *server {
+	t.Helper()
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Skipf("hunspell not available for testing: %v", err)
+	}
+	s, err := hunspell.NewSpell(testPath, testLang)
+	if err != nil {
+		t.Fatalf("failed to open dictionary: %v", err)
+	}
+	return newServer(s, userDict)
+}
+
+func TestCodeActions(t *testing.T) {
+	const uri = "file:///a.go"
+	srv := newTestServer(t, "")
+	srv.docs[uri] = &document{languageID: "go", text: `package p
+
+// a comment with a langauge typo
+`}
+
+	actions := srv.codeActions(codeActionParams{
+		TextDocument: textDocumentIdentifier{URI: uri},
+		Range:        rng{Start: position{Line: 2, Character: 0}, End: position{Line: 2, Character: 40}},
+	})
+	if len(actions) == 0 {
+		t.Fatal("no code actions returned for a misspelling in range")
+	}
+
+	var (
+		sawFix bool
+		sawAdd bool
+	)
+	for _, a := range actions {
+		if a.Edit != nil {
+			sawFix = true
+			edits := a.Edit.Changes[uri]
+			if len(edits) != 1 {
+				t.Errorf("edit action %q has %d edits, want 1", a.Title, len(edits))
+			}
+		}
+		if a.Command != nil {
+			if a.Command.Command != addWordCommand {
+				t.Errorf("unexpected command: %q", a.Command.Command)
+			}
+			sawAdd = true
+		}
+	}
+	if !sawFix {
+		t.Error("no suggestion quick fix offered")
+	}
+	if !sawAdd {
+		t.Error(`no "add to dictionary" action offered`)
+	}
+
+	outOfRange := srv.codeActions(codeActionParams{
+		TextDocument: textDocumentIdentifier{URI: uri},
+		Range:        rng{Start: position{Line: 0, Character: 0}, End: position{Line: 0, Character: 1}},
+	})
+	if len(outOfRange) != 0 {
+		t.Errorf("got %d actions for a range with no misspelling, want 0", len(outOfRange))
+	}
+}
+
+func TestExecuteCommandPersistsUserDictionary(t *testing.T) {
+	userDict := filepath.Join(t.TempDir(), "user.dic")
+	srv := newTestServer(t, userDict)
+
+	if srv.spell.IsCorrect("langauge") {
+		t.Fatal("test word is unexpectedly already correct")
+	}
+
+	var notifications []interface{}
+	send := func(v interface{}) error {
+		notifications = append(notifications, v)
+		return nil
+	}
+
+	const uri = "file:///a.txt"
+	srv.docs[uri] = &document{languageID: "text", text: "a langauge typo"}
+
+	srv.executeCommand(executeCommandParams{
+		Command:   addWordCommand,
+		Arguments: []interface{}{"langauge", uri},
+	}, send)
+
+	if !srv.spell.IsCorrect("langauge") {
+		t.Error("word was not added to the run-time dictionary")
+	}
+
+	data, err := os.ReadFile(userDict)
+	if err != nil {
+		t.Fatalf("user dictionary was not persisted: %v", err)
+	}
+	if got := strings.TrimSpace(string(data)); got != "langauge" {
+		t.Errorf("user dictionary contents = %q, want %q", got, "langauge")
+	}
+
+	if len(notifications) != 1 {
+		t.Fatalf("got %d notifications, want 1 republished diagnostics notification", len(notifications))
+	}
+	n, ok := notifications[0].(notification)
+	if !ok {
+		t.Fatalf("unexpected notification type %T", notifications[0])
+	}
+	if n.Method != "textDocument/publishDiagnostics" {
+		t.Errorf("unexpected notification method %q", n.Method)
+	}
+	params, ok := n.Params.(publishDiagnosticsParams)
+	if !ok {
+		t.Fatalf("unexpected notification params type %T", n.Params)
+	}
+	for _, d := range params.Diagnostics {
+		if strings.Contains(d.Message, "langauge") {
+			t.Errorf("diagnostics still report the now-added word: %+v", d)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// NewSpellFS returns a spelling checker initialized with the dictionary
+// specified by the lang key, read from fsys. Since libhunspell only
+// accepts file system paths, the affix rule and dictionary files are
+// copied out to temporary files. Those temporary files must outlive the
+// returned Spell, since Watch needs them to still be present to observe
+// and reload from, so they are kept alive for the Spell's lifetime and
+// removed by its finalizer rather than as soon as NewSpellFS returns.
+func NewSpellFS(fsys fs.FS, lang string) (*Spell, error) {
+	affPath, dictPath, err := Paths("", lang)
+	if err != nil {
+		return nil, err
+	}
+
+	aff, cleanup, err := materialize(fsys, affPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dict, cleanup2, err := materialize(fsys, dictPath)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	s, err := NewSpellPaths(aff, dict)
+	if err != nil {
+		cleanup()
+		cleanup2()
+		return nil, err
+	}
+	s.tmpFiles = append(s.tmpFiles, aff, dict)
+	return s, nil
+}
+
+// AddDictFS adds the extra dictionary (.dic file) at path in fsys to the
+// run-time dictionary. The materialized temporary file is kept alive for
+// s's lifetime and removed by its finalizer, alongside the files
+// NewSpellFS may have materialized, so that it remains in place for
+// SafeSpell.Watch to observe and reload from.
+func (s *Spell) AddDictFS(fsys fs.FS, path string) error {
+	dict, cleanup, err := materialize(fsys, path)
+	if err != nil {
+		return err
+	}
+	if err := s.AddDict(dict); err != nil {
+		cleanup()
+		return err
+	}
+	s.tmpFiles = append(s.tmpFiles, dict)
+	return nil
+}
+
+// materialize copies the file at path in fsys to a temporary file and
+// returns its path and a function that removes it. Callers that go on
+// to use the path to build or extend a Spell keep the temporary file
+// alive for that Spell's lifetime instead of invoking cleanup; cleanup
+// is only used to unwind a materialized file that the caller ends up
+// not using, for example when a later step in the same call fails.
+func materialize(fsys fs.FS, path string) (tmpPath string, cleanup func(), err error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, err := os.CreateTemp("", "hunspell-*"+filepath.Ext(path))
+	if err != nil {
+		return "", nil, err
+	}
+	tmpPath = f.Name()
+	_, werr := f.Write(data)
+	cerr := f.Close()
+	if werr != nil || cerr != nil {
+		os.Remove(tmpPath)
+		if werr != nil {
+			return "", nil, werr
+		}
+		return "", nil, cerr
+	}
+
+	cleanup = func() { os.Remove(tmpPath) }
+	return tmpPath, cleanup, nil
+}
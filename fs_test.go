@@ -0,0 +1,41 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestNewSpellFS(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Fatalf("hunspell not available for testing: %v", err)
+	}
+
+	s, err := NewSpellFS(os.DirFS(path), lang)
+	if err != nil {
+		t.Fatalf("failed to open dictionary: %v", err)
+	}
+	for _, w := range words {
+		got := s.IsCorrect(w)
+		want := wantSuggest[w][0] == w
+		if got != want {
+			t.Errorf("unexpected result for %q is correct: got:%t want:%t", w, got, want)
+		}
+	}
+
+	if s.IsCorrect("colour") {
+		t.Error("absent word is incorrectly accepted")
+	}
+	err = s.AddDictFS(os.DirFS("testdata"), "en_au.dic")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if !s.IsCorrect("colour") {
+		t.Error("word added by dictionary is still incorrect")
+	}
+}
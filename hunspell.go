@@ -22,6 +22,19 @@ import (
 // returned by a successful call to NewSpell or NewSpellPaths.
 type Spell struct {
 	handle *C.Hunhandle
+
+	// affix and dict record the paths passed to NewSpellPaths, and
+	// extraDicts the paths passed to AddDict, so that SafeSpell.Watch
+	// knows which files to observe and how to rebuild the handle.
+	affix, dict string
+	extraDicts  []string
+
+	// tmpFiles records paths materialized from an fs.FS by NewSpellFS
+	// or AddDictFS. They are removed by the finalizer set in
+	// NewSpellPaths alongside the underlying handle, since libhunspell
+	// only accepts paths and the files must outlive the call that
+	// created the handle.
+	tmpFiles []string
 }
 
 // NewSpell returns a spelling checker initialized with the dictionary
@@ -72,9 +85,12 @@ func NewSpellPaths(affix, dict string) (*Spell, error) {
 	}
 	affC := C.CString(affix)
 	dictC := C.CString(dict)
-	s := &Spell{handle: C.Hunspell_create(affC, dictC)}
+	s := &Spell{handle: C.Hunspell_create(affC, dictC), affix: affix, dict: dict}
 	runtime.SetFinalizer(s, func(h *Spell) {
 		C.Hunspell_destroy(h.handle)
+		for _, p := range h.tmpFiles {
+			os.Remove(p)
+		}
 	})
 	C.free(unsafe.Pointer(affC))
 	C.free(unsafe.Pointer(dictC))
@@ -135,6 +151,7 @@ func (s *Spell) AddDict(path string) error {
 	if C.Hunspell_add_dic(s.handle, p) == 1 {
 		return errors.New("failed to add dictionary")
 	}
+	s.extraDicts = append(s.extraDicts, path)
 	return nil
 }
 
@@ -0,0 +1,98 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import "errors"
+
+// Pool is a set of independent spelling checkers constructed from the
+// same affix rule and dictionary files. Since libhunspell's handle is
+// not safe for any concurrent call, not even reads, Pool checks a
+// handle out of a buffered channel for the duration of each query and
+// checks it back in afterwards, so that callers can check large corpora
+// in parallel across its members without two callers ever using the
+// same handle at once.
+//
+// Pool does not support Add, AddWithAffix, Remove or AddDict; callers
+// that need a shared mutable dictionary should use SafeSpell instead.
+type Pool struct {
+	spells chan *Spell
+}
+
+// NewPool returns a Pool of n independent spelling checkers, each
+// initialized with the dictionary specified by the lang key located in
+// the given path.
+func NewPool(n int, path, lang string) (*Pool, error) {
+	affPath, dictPath, err := Paths(path, lang)
+	if err != nil {
+		return nil, err
+	}
+	return NewPoolPaths(n, affPath, dictPath)
+}
+
+// NewPoolPaths returns a Pool of n independent spelling checkers, each
+// initialized with the dictionary specified by the affix rule and
+// dictionary files.
+func NewPoolPaths(n int, affix, dict string) (*Pool, error) {
+	if n < 1 {
+		return nil, errors.New("hunspell: invalid pool size")
+	}
+	p := &Pool{spells: make(chan *Spell, n)}
+	for i := 0; i < n; i++ {
+		s, err := NewSpellPaths(affix, dict)
+		if err != nil {
+			return nil, err
+		}
+		p.spells <- s
+	}
+	return p, nil
+}
+
+// Len returns the number of spelling checkers held by the pool.
+func (p *Pool) Len() int {
+	return cap(p.spells)
+}
+
+// get checks out a spelling checker, blocking until one is available,
+// so that no two callers are ever handed the same handle at once.
+func (p *Pool) get() *Spell {
+	return <-p.spells
+}
+
+// put checks s back in so it can be handed to another caller.
+func (p *Pool) put(s *Spell) {
+	p.spells <- s
+}
+
+// IsCorrect returns whether the provided word is spelled correctly. It
+// may be called concurrently.
+func (p *Pool) IsCorrect(word string) bool {
+	s := p.get()
+	defer p.put(s)
+	return s.IsCorrect(word)
+}
+
+// Suggest returns suggestions for the provided word. It may be called
+// concurrently.
+func (p *Pool) Suggest(word string) []string {
+	s := p.get()
+	defer p.put(s)
+	return s.Suggest(word)
+}
+
+// Analyze returns a morphological analysis of the word. It may be called
+// concurrently.
+func (p *Pool) Analyze(word string) []string {
+	s := p.get()
+	defer p.put(s)
+	return s.Analyze(word)
+}
+
+// Stem returns the stems of the provided word. It may be called
+// concurrently.
+func (p *Pool) Stem(word string) []string {
+	s := p.get()
+	defer p.put(s)
+	return s.Stem(word)
+}
@@ -0,0 +1,86 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Fatalf("hunspell not available for testing: %v", err)
+	}
+
+	p, err := NewPool(4, path, lang)
+	if err != nil {
+		t.Fatalf("failed to open pool: %v", err)
+	}
+	if p.Len() != 4 {
+		t.Errorf("unexpected pool size: got:%d want:4", p.Len())
+	}
+	for _, w := range words {
+		got := p.IsCorrect(w)
+		want := wantSuggest[w][0] == w
+		if got != want {
+			t.Errorf("unexpected result for %q is correct: got:%t want:%t", w, got, want)
+		}
+	}
+}
+
+func TestSafeSpell(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Fatalf("hunspell not available for testing: %v", err)
+	}
+
+	s, err := NewSpell(path, lang)
+	if err != nil {
+		t.Fatalf("failed to open dictionary: %v", err)
+	}
+	ss := NewSafeSpell(s)
+
+	ss.Add("seperate")
+	if !ss.IsCorrect("seperate") {
+		t.Error("added word still incorrect")
+	}
+	ss.Remove("seperate")
+	if ss.IsCorrect("seperate") {
+		t.Error("removed word still correct")
+	}
+}
+
+func BenchmarkSuggestSerial(b *testing.B) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		b.Skipf("hunspell not available for testing: %v", err)
+	}
+
+	s, err := NewSpell(path, lang)
+	if err != nil {
+		b.Fatalf("failed to open dictionary: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Suggest("langauge")
+	}
+}
+
+func BenchmarkSuggestPool(b *testing.B) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		b.Skipf("hunspell not available for testing: %v", err)
+	}
+
+	p, err := NewPool(4, path, lang)
+	if err != nil {
+		b.Fatalf("failed to open pool: %v", err)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			p.Suggest("langauge")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import "sync"
+
+// SafeSpell is a concurrency-safe wrapper around a Spell. libhunspell's
+// handle is not safe for concurrent use at all: its suggest and analysis
+// paths mutate internal state even for read-only calls such as Suggest
+// and Analyze, so SafeSpell serialises every call, read or write, on a
+// single mutex. Callers that need real read concurrency should use Pool,
+// which dispatches across independent handles instead of sharing one.
+type SafeSpell struct {
+	mu sync.Mutex
+	s  *Spell
+}
+
+// NewSafeSpell returns a SafeSpell wrapping s. s must not be used
+// directly after it has been passed to NewSafeSpell.
+func NewSafeSpell(s *Spell) *SafeSpell {
+	return &SafeSpell{s: s}
+}
+
+// IsCorrect returns whether the provided word is spelled correctly.
+func (s *SafeSpell) IsCorrect(word string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.IsCorrect(word)
+}
+
+// Suggest returns suggestions for the provided word.
+func (s *SafeSpell) Suggest(word string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Suggest(word)
+}
+
+// Analyze returns a morphological analysis of the word.
+func (s *SafeSpell) Analyze(word string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Analyze(word)
+}
+
+// Stem returns the stems of the provided word.
+func (s *SafeSpell) Stem(word string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Stem(word)
+}
+
+// Add adds the provided word to the run-time dictionary.
+func (s *SafeSpell) Add(word string) (ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Add(word)
+}
+
+// AddWithAffix adds the provided word to the run-time dictionary including
+// affix information from the dictionary example word.
+func (s *SafeSpell) AddWithAffix(word, example string) (ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.AddWithAffix(word, example)
+}
+
+// Remove removes the provided word from the run-time dictionary.
+func (s *SafeSpell) Remove(word string) (ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Remove(word)
+}
+
+// AddDict adds extra dictionary (.dic file) to the run-time dictionary.
+func (s *SafeSpell) AddDict(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.AddDict(path)
+}
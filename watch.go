@@ -0,0 +1,137 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ReloadEvent describes the result of reloading a dictionary in response
+// to a change observed by SafeSpell.Watch.
+type ReloadEvent struct {
+	// Path is the file that changed.
+	Path string
+	// Err is non-nil if the reload failed. The previous handle remains
+	// in use in that case.
+	Err error
+}
+
+// Watch uses fsnotify to observe the affix rule and dictionary files
+// that s was built from, along with any files added with AddDict, and
+// rebuilds the underlying handle whenever one of them changes. The new
+// handle is swapped in while holding s's mutex, so that concurrent callers
+// see either the old or the new dictionary but never a torn state.
+//
+// Watch observes the parent directory of each file rather than the
+// file itself, so that an atomic replace (a temp-file-plus-rename, or a
+// symlink swap) is picked up the same as an in-place write; watching
+// the file directly would silently stop seeing changes once the
+// original inode is replaced.
+//
+// The returned channel is closed, and the watch stopped, when ctx is
+// done; events sent to it should be drained by the caller to avoid
+// blocking the watch goroutine.
+func (s *SafeSpell) Watch(ctx context.Context) (<-chan ReloadEvent, error) {
+	s.mu.Lock()
+	affix, dict := s.s.affix, s.s.dict
+	extraDicts := append([]string(nil), s.s.extraDicts...)
+	s.mu.Unlock()
+
+	paths := watchedPaths(affix, dict, extraDicts)
+	watched := make(map[string]bool, len(paths))
+	dirs := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		watched[filepath.Clean(p)] = true
+		dirs[filepath.Dir(p)] = true
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+
+	events := make(chan ReloadEvent)
+	go func() {
+		defer w.Close()
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				select {
+				case events <- ReloadEvent{Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if !watched[filepath.Clean(ev.Name)] {
+					continue
+				}
+				if ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				select {
+				case events <- s.reload(affix, dict, extraDicts, ev.Name):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// watchedPaths returns the non-empty paths among affix, dict and extraDicts.
+func watchedPaths(affix, dict string, extraDicts []string) []string {
+	var paths []string
+	if affix != "" {
+		paths = append(paths, affix)
+	}
+	if dict != "" {
+		paths = append(paths, dict)
+	}
+	return append(paths, extraDicts...)
+}
+
+// reload rebuilds the handle from affix, dict and extraDicts and swaps
+// it into s while holding s's mutex.
+func (s *SafeSpell) reload(affix, dict string, extraDicts []string, changed string) ReloadEvent {
+	fresh, err := NewSpellPaths(affix, dict)
+	if err != nil {
+		return ReloadEvent{Path: changed, Err: err}
+	}
+	for _, d := range extraDicts {
+		if err := fresh.AddDict(d); err != nil {
+			return ReloadEvent{Path: changed, Err: err}
+		}
+	}
+
+	s.mu.Lock()
+	// affix, dict and extraDicts may be paths materialized from an
+	// fs.FS by NewSpellFS/AddDictFS. fresh reads from the same paths,
+	// so it takes over their cleanup; clear them on the outgoing Spell
+	// so its finalizer doesn't remove files fresh still depends on.
+	fresh.tmpFiles = s.s.tmpFiles
+	s.s.tmpFiles = nil
+	s.s = fresh
+	s.mu.Unlock()
+	return ReloadEvent{Path: changed}
+}
@@ -0,0 +1,119 @@
+// Copyright ©2022 Dan Kortschak. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hunspell
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Fatalf("hunspell not available for testing: %v", err)
+	}
+
+	dir := t.TempDir()
+	affix := filepath.Join(dir, lang+".aff")
+	dict := filepath.Join(dir, lang+".dic")
+	copyFile(t, filepath.Join(path, lang+".aff"), affix)
+	copyFile(t, filepath.Join(path, lang+".dic"), dict)
+
+	s, err := NewSpellPaths(affix, dict)
+	if err != nil {
+		t.Fatalf("failed to open dictionary: %v", err)
+	}
+	ss := NewSafeSpell(s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := ss.Watch(ctx)
+	if err != nil {
+		t.Fatalf("failed to watch dictionary: %v", err)
+	}
+
+	copyFile(t, filepath.Join(path, lang+".dic"), dict)
+
+	select {
+	case ev := <-events:
+		if ev.Err != nil {
+			t.Errorf("unexpected reload error: %v", ev.Err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload event")
+	}
+
+	if !ss.IsCorrect("language") {
+		t.Error("reloaded dictionary rejects known word")
+	}
+}
+
+func TestWatchRename(t *testing.T) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		t.Fatalf("hunspell not available for testing: %v", err)
+	}
+
+	dir := t.TempDir()
+	affix := filepath.Join(dir, lang+".aff")
+	dict := filepath.Join(dir, lang+".dic")
+	copyFile(t, filepath.Join(path, lang+".aff"), affix)
+	copyFile(t, filepath.Join(path, lang+".dic"), dict)
+
+	s, err := NewSpellPaths(affix, dict)
+	if err != nil {
+		t.Fatalf("failed to open dictionary: %v", err)
+	}
+	ss := NewSafeSpell(s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := ss.Watch(ctx)
+	if err != nil {
+		t.Fatalf("failed to watch dictionary: %v", err)
+	}
+
+	// Simulate an atomic replace: write the new dictionary to a
+	// sibling temp file and rename it over dict, rather than
+	// overwriting dict in place.
+	tmp := dict + ".tmp"
+	copyFile(t, filepath.Join(path, lang+".dic"), tmp)
+	if err := os.Rename(tmp, dict); err != nil {
+		t.Fatalf("failed to rename %s to %s: %v", tmp, dict, err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Err != nil {
+			t.Errorf("unexpected reload error: %v", ev.Err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload event")
+	}
+
+	if !ss.IsCorrect("language") {
+		t.Error("reloaded dictionary rejects known word")
+	}
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	in, err := os.Open(src)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", src, err)
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", dst, err)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		t.Fatalf("failed to copy %s to %s: %v", src, dst, err)
+	}
+}